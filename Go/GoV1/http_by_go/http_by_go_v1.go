@@ -2,20 +2,40 @@ package main
 
 import (
 	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
 	"fmt"
+	"html"
+	"io"
 	"log"
 	"net"
+	"os"
+	"os/signal"
+	"runtime/debug"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 )
 
+const (
+	headerReadTimeout  = 5 * time.Second  // 요청 헤더를 읽는 제한 시간
+	idleTimeout        = 60 * time.Second // keep-alive 연결의 유휴 제한 시간
+	defaultStopTimeout = 10 * time.Second // Shutdown 신호 후 진행 중인 연결을 기다리는 기본 유예 시간
+)
+
 // HTTPRequest는 HTTP 요청을 파싱한 결과를 담습니다
 type HTTPRequest struct {
 	Method  string            // GET, POST 등
 	Path    string            // /hello, /about 등
 	Version string            // HTTP/1.1
 	Headers map[string]string // Host, User-Agent 등
-	Body    string            // 요청 본문
+	Params  map[string]string // 경로 파라미터 (/users/:id -> {"id": "42"})
+	Body    io.Reader         // 요청 본문 (Content-Length 또는 chunked 본문)
 }
 
 // HTTPResponse는 HTTP 응답을 나타냅니다
@@ -23,99 +43,552 @@ type HTTPResponse struct {
 	StatusCode int
 	StatusText string
 	Headers    map[string]string
-	Body       string
+	Body       io.Reader // 응답 본문. Transfer-Encoding: chunked면 다 읽지 않고 그대로 스트리밍합니다
 }
 
+// Handler는 요청을 처리해 응답을 돌려주는 함수입니다
+type Handler func(*HTTPRequest) *HTTPResponse
+
+// Middleware는 Handler를 감싸 로깅, 인증, 복구 등의 공통 동작을 추가합니다
+type Middleware func(Handler) Handler
+
 // Server는 우리가 만든 HTTP 서버입니다
 type Server struct {
-	address string
-	routes  map[string]func(*HTTPRequest) *HTTPResponse
+	address       string
+	root          *routeNode
+	middlewares   []Middleware
+	ShutdownGrace time.Duration // Shutdown 신호 후 진행 중인 연결을 기다리는 유예 시간
+	TLSConfig     *tls.Config   // 설정하면 StartTLS가 이 설정을 그대로 사용 (SNI용 GetCertificate 등)
+	HeaderTimeout time.Duration // 요청 헤더를 읽는 제한 시간 (첫 요청은 연결 직후부터, 이후 요청은 첫 바이트가 도착한 뒤부터)
+	IdleTimeout   time.Duration // keep-alive 연결에서 다음 요청의 첫 바이트를 기다리는 제한 시간
+
+	listener net.Listener
+	conns    map[net.Conn]struct{}
+	connsMu  sync.Mutex
+	wg       sync.WaitGroup
+	closing  int32 // atomic: 1이면 리스너가 의도적으로 닫힌 것
 }
 
 // NewServer는 새로운 서버를 생성합니다
 func NewServer(address string) *Server {
 	return &Server{
-		address: address,
-		routes:  make(map[string]func(*HTTPRequest) *HTTPResponse),
+		address:       address,
+		root:          newRouteNode(),
+		ShutdownGrace: defaultStopTimeout,
+		HeaderTimeout: headerReadTimeout,
+		IdleTimeout:   idleTimeout,
+		conns:         make(map[net.Conn]struct{}),
+	}
+}
+
+// Use는 모든 요청에 적용할 미들웨어를 등록합니다. 먼저 등록한 미들웨어가 바깥쪽에서 실행됩니다
+func (s *Server) Use(middleware ...Middleware) {
+	s.middlewares = append(s.middlewares, middleware...)
+}
+
+// Handle은 지정한 메서드와 경로에 대한 핸들러를 등록합니다.
+// 경로에는 ":id" 같은 파라미터와 "*path" 같은 와일드카드를 쓸 수 있습니다
+func (s *Server) Handle(method, path string, handler Handler) {
+	s.root.insert(splitPath(path), strings.ToUpper(method), handler)
+}
+
+func (s *Server) GET(path string, handler Handler)     { s.Handle("GET", path, handler) }
+func (s *Server) POST(path string, handler Handler)    { s.Handle("POST", path, handler) }
+func (s *Server) PUT(path string, handler Handler)     { s.Handle("PUT", path, handler) }
+func (s *Server) DELETE(path string, handler Handler)  { s.Handle("DELETE", path, handler) }
+func (s *Server) HEAD(path string, handler Handler)    { s.Handle("HEAD", path, handler) }
+func (s *Server) OPTIONS(path string, handler Handler) { s.Handle("OPTIONS", path, handler) }
+
+// routeNode는 경로 트리의 한 구간(segment)을 나타냅니다
+type routeNode struct {
+	children      map[string]*routeNode // 정적 구간 -> 자식 노드
+	paramChild    *routeNode            // ":name" 파라미터 구간의 자식 노드
+	paramName     string
+	wildcardChild *routeNode // "*name" 와일드카드 구간의 자식 노드 (남은 경로를 모두 소비)
+	wildcardName  string
+	handlers      map[string]Handler // 이 노드에 등록된 메서드 -> 핸들러
+}
+
+func newRouteNode() *routeNode {
+	return &routeNode{
+		children: make(map[string]*routeNode),
+		handlers: make(map[string]Handler),
+	}
+}
+
+// splitPath는 "/users/42" -> ["users", "42"], "/" -> [] 로 경로를 구간 단위로 나눕니다
+func splitPath(path string) []string {
+	trimmed := strings.Trim(path, "/")
+	if trimmed == "" {
+		return nil
+	}
+	return strings.Split(trimmed, "/")
+}
+
+func (n *routeNode) insert(segments []string, method string, handler Handler) {
+	if len(segments) == 0 {
+		n.handlers[method] = handler
+		return
+	}
+
+	segment := segments[0]
+	switch {
+	case strings.HasPrefix(segment, ":"):
+		if n.paramChild == nil {
+			n.paramChild = newRouteNode()
+			n.paramName = segment[1:]
+		}
+		n.paramChild.insert(segments[1:], method, handler)
+	case strings.HasPrefix(segment, "*"):
+		if n.wildcardChild == nil {
+			n.wildcardChild = newRouteNode()
+			n.wildcardName = segment[1:]
+		}
+		// 와일드카드는 남은 경로를 모두 소비하는 종점이므로 나머지 구간은 무시합니다
+		n.wildcardChild.handlers[method] = handler
+	default:
+		child, exists := n.children[segment]
+		if !exists {
+			child = newRouteNode()
+			n.children[segment] = child
+		}
+		child.insert(segments[1:], method, handler)
 	}
 }
 
-// HandleFunc는 특정 경로에 대한 핸들러를 등록합니다
-func (s *Server) HandleFunc(path string, handler func(*HTTPRequest) *HTTPResponse) {
-	s.routes[path] = handler
+// find는 경로를 따라 내려가며 일치하는 노드를 찾고, 지나가며 만난 파라미터를 params에 채웁니다
+func (n *routeNode) find(segments []string, params map[string]string) (*routeNode, bool) {
+	if len(segments) == 0 {
+		// 핸들러가 하나도 없는 중간 노드(등록된 경로의 접두사)는 일치로 보지 않습니다
+		return n, len(n.handlers) > 0
+	}
+
+	segment := segments[0]
+
+	if child, ok := n.children[segment]; ok {
+		if found, ok := child.find(segments[1:], params); ok {
+			return found, true
+		}
+	}
+
+	if n.paramChild != nil {
+		params[n.paramName] = segment
+		if found, ok := n.paramChild.find(segments[1:], params); ok {
+			return found, true
+		}
+		delete(params, n.paramName)
+	}
+
+	if n.wildcardChild != nil {
+		params[n.wildcardName] = strings.Join(segments, "/")
+		return n.wildcardChild, true
+	}
+
+	return nil, false
 }
 
-// Start는 서버를 시작합니다
+// allowedMethods는 노드에 등록된 메서드 목록(OPTIONS 포함)을 정렬해서 돌려줍니다
+func allowedMethods(node *routeNode) []string {
+	methods := make([]string, 0, len(node.handlers)+1)
+	for method := range node.handlers {
+		methods = append(methods, method)
+	}
+	if _, hasOptions := node.handlers["OPTIONS"]; !hasOptions {
+		methods = append(methods, "OPTIONS")
+	}
+	sort.Strings(methods)
+	return methods
+}
+
+// match는 요청 경로/메서드에 맞는 핸들러(미들웨어가 적용된)를 찾습니다.
+// 경로는 맞지만 메서드가 등록되어 있지 않으면 allowed 메서드 목록과 함께 pathFound=true를 돌려줍니다
+func (s *Server) match(method, path string) (handler Handler, params map[string]string, allowed []string, pathFound bool) {
+	params = make(map[string]string)
+	node, ok := s.root.find(splitPath(path), params)
+	if !ok {
+		return nil, params, nil, false
+	}
+
+	if h, exists := node.handlers[method]; exists {
+		return s.wrap(h), params, nil, true
+	}
+
+	return nil, params, allowedMethods(node), true
+}
+
+// wrap은 등록된 미들웨어를 핸들러에 순서대로 적용합니다
+func (s *Server) wrap(handler Handler) Handler {
+	for i := len(s.middlewares) - 1; i >= 0; i-- {
+		handler = s.middlewares[i](handler)
+	}
+	return handler
+}
+
+// Start는 서버를 평문 HTTP로 시작합니다. SIGINT/SIGTERM을 받으면 ShutdownGrace
+// 동안 진행 중인 연결을 기다린 뒤 정상 종료합니다.
 func (s *Server) Start() error {
-	// TCP 리스너 생성 (클라이언트 연결을 기다림)
 	listener, err := net.Listen("tcp", s.address)
 	if err != nil {
 		return fmt.Errorf("리스너 생성 실패: %v", err)
 	}
-	defer listener.Close()
 
 	fmt.Printf("🚀 서버가 %s 에서 시작되었습니다!\n", s.address)
 	fmt.Printf("📡 브라우저에서 http://localhost:8080 접속해보세요\n\n")
 
+	return s.serve(listener)
+}
+
+// StartTLS는 같은 라우팅/미들웨어 엔진으로 HTTPS 리스너를 엽니다. TLSConfig가
+// 이미 설정되어 있으면(예: SNI용 GetCertificate) 그대로 쓰고, 그렇지 않으면
+// certFile/keyFile로 인증서를 읽어 채웁니다. handleConnection은 net.Conn만
+// 다루므로 tls.Conn도 그대로 재사용할 수 있습니다.
+func (s *Server) StartTLS(certFile, keyFile string) error {
+	tlsConfig := s.TLSConfig
+	if tlsConfig == nil {
+		tlsConfig = &tls.Config{}
+	}
+
+	if tlsConfig.GetCertificate == nil && len(tlsConfig.Certificates) == 0 {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return fmt.Errorf("인증서 로드 실패: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	listener, err := tls.Listen("tcp", s.address, tlsConfig)
+	if err != nil {
+		return fmt.Errorf("TLS 리스너 생성 실패: %v", err)
+	}
+
+	fmt.Printf("🔒 서버가 %s 에서 HTTPS로 시작되었습니다!\n\n", s.address)
+
+	return s.serve(listener)
+}
+
+// serve는 accept 루프를 돌며 연결마다 handleConnection을 고루틴으로 실행합니다.
+// Start와 StartTLS가 이 메서드를 공유합니다.
+func (s *Server) serve(listener net.Listener) error {
+	s.listener = listener
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigCh
+		fmt.Println("\n🛑 종료 신호를 받았습니다. 진행 중인 연결을 정리합니다...")
+		ctx, cancel := context.WithTimeout(context.Background(), s.ShutdownGrace)
+		defer cancel()
+		if err := s.Shutdown(ctx); err != nil {
+			log.Printf("서버 종료 중 오류: %v", err)
+		}
+	}()
+
 	// 무한 루프: 계속 클라이언트 연결을 받습니다
 	for {
 		// 클라이언트 연결을 기다립니다 (블로킹)
 		conn, err := listener.Accept()
 		if err != nil {
+			if atomic.LoadInt32(&s.closing) == 1 {
+				// Shutdown이 리스너를 닫아서 생긴 정상적인 종료
+				return nil
+			}
 			log.Printf("연결 수락 실패: %v", err)
 			continue
 		}
 
+		s.trackConn(conn)
+		s.wg.Add(1)
+
 		// 각 클라이언트를 별도 고루틴에서 처리 (동시 처리)
-		go s.handleConnection(conn)
+		go func() {
+			defer s.wg.Done()
+			defer s.untrackConn(conn)
+			s.handleConnection(conn)
+		}()
 	}
 }
 
-// handleConnection은 클라이언트 연결을 처리합니다
-func (s *Server) handleConnection(conn net.Conn) {
-	defer conn.Close()
+// RedirectToHTTPS는 addr(보통 ":80")에서 평문 HTTP 요청을 받아 같은 호스트의
+// HTTPS URL로 301 리다이렉트하는 별도의 리스너를 엽니다.
+func RedirectToHTTPS(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("리다이렉트 리스너 생성 실패: %v", err)
+	}
+	defer listener.Close()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			log.Printf("리다이렉트 연결 수락 실패: %v", err)
+			continue
+		}
+		go handleRedirect(conn)
+	}
+}
 
-	// 연결 시간 제한 설정 (30초)
-	conn.SetDeadline(time.Now().Add(30 * time.Second))
+// handleRedirect는 요청 한 개를 읽어 같은 경로의 https:// URL로 301 응답을 보냅니다
+func handleRedirect(conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(headerReadTimeout))
 
-	// HTTP 요청 읽기
-	request, err := s.parseRequest(conn)
+	reader := bufio.NewReader(conn)
+	requestLine, err := reader.ReadString('\n')
 	if err != nil {
-		log.Printf("❌ 요청 파싱 실패: %v", err)
 		return
 	}
+	parts := strings.Split(strings.TrimSpace(requestLine), " ")
+	if len(parts) != 3 {
+		return
+	}
+	path := parts[1]
 
-	// 요청 정보 출력
-	fmt.Printf("📥 [%s] %s %s\n", time.Now().Format("15:04:05"), request.Method, request.Path)
+	var host string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		if i := strings.Index(line, ":"); i > 0 && strings.EqualFold(strings.TrimSpace(line[:i]), "Host") {
+			host = strings.TrimSpace(line[i+1:])
+		}
+	}
+
+	location := httpsLocation(host, path)
+	body := redirectBody(location)
+	response := fmt.Sprintf(
+		"HTTP/1.1 301 Moved Permanently\r\nLocation: %s\r\nContent-Type: text/html; charset=utf-8\r\nContent-Length: %d\r\nConnection: close\r\n\r\n%s",
+		location, len(body), body,
+	)
+	conn.Write([]byte(response))
+}
+
+// RequireHTTPS는 리버스 프록시 뒤에서 동작할 때 X-Forwarded-Proto 헤더로 HTTPS
+// 여부를 확인하는 미들웨어입니다. https가 아니면 같은 경로의 https URL로 301
+// 리다이렉트합니다.
+func RequireHTTPS(next Handler) Handler {
+	return func(req *HTTPRequest) *HTTPResponse {
+		if strings.EqualFold(req.Headers["X-Forwarded-Proto"], "https") {
+			return next(req)
+		}
 
-	// 라우팅: 경로에 맞는 핸들러 찾기
-	var response *HTTPResponse
-	if handler, exists := s.routes[request.Path]; exists {
-		response = handler(request)
-	} else {
-		response = &HTTPResponse{
-			StatusCode: 404,
-			StatusText: "Not Found",
-			Body:       "<h1>404 - 페이지를 찾을 수 없습니다</h1>",
+		location := httpsLocation(req.Headers["Host"], req.Path)
+		return &HTTPResponse{
+			StatusCode: 301,
+			StatusText: "Moved Permanently",
+			Headers:    map[string]string{"Location": location},
+			Body:       strings.NewReader(redirectBody(location)),
 		}
 	}
+}
+
+// httpsLocation은 host/path로 https:// URL을 만듭니다. 헤더 삽입을 막기 위해
+// CR/LF는 Location 헤더에 들어가기 전에 제거합니다
+func httpsLocation(host, path string) string {
+	strip := func(s string) string {
+		return strings.NewReplacer("\r", "", "\n", "").Replace(s)
+	}
+	return fmt.Sprintf("https://%s%s", strip(host), strip(path))
+}
+
+// redirectBody는 location을 HTML 이스케이프해서 301 응답 본문에 안전하게 끼워 넣습니다
+func redirectBody(location string) string {
+	escaped := html.EscapeString(location)
+	return fmt.Sprintf("<h1>301 Moved Permanently</h1><p><a href=\"%s\">%s</a></p>", escaped, escaped)
+}
 
-	// 응답 헤더가 없으면 기본값 설정
-	if response.Headers == nil {
-		response.Headers = make(map[string]string)
+// Shutdown은 새 연결 수락을 멈추고, 진행 중인 handleConnection 고루틴이 끝나기를
+// 기다립니다. ctx가 먼저 끝나면 남은 연결을 강제로 닫고 ctx.Err()를 돌려줍니다.
+func (s *Server) Shutdown(ctx context.Context) error {
+	atomic.StoreInt32(&s.closing, 1)
+	if s.listener != nil {
+		s.listener.Close()
 	}
-	response.Headers["Content-Type"] = "text/html; charset=utf-8"
-	response.Headers["Content-Length"] = fmt.Sprintf("%d", len(response.Body))
-	response.Headers["Server"] = "MySimpleHTTPServer/1.0"
 
-	// HTTP 응답 전송
-	s.sendResponse(conn, response)
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.connsMu.Lock()
+		for conn := range s.conns {
+			conn.Close()
+		}
+		s.connsMu.Unlock()
+		<-done
+		return ctx.Err()
+	}
+}
+
+func (s *Server) trackConn(conn net.Conn) {
+	s.connsMu.Lock()
+	s.conns[conn] = struct{}{}
+	s.connsMu.Unlock()
+}
+
+func (s *Server) untrackConn(conn net.Conn) {
+	s.connsMu.Lock()
+	delete(s.conns, conn)
+	s.connsMu.Unlock()
 }
 
-// parseRequest는 TCP 연결에서 HTTP 요청을 읽어서 파싱합니다
-func (s *Server) parseRequest(conn net.Conn) (*HTTPRequest, error) {
+// handleConnection은 클라이언트 연결을 처리합니다. keep-alive 연결이면
+// 클라이언트가 닫을 때까지(또는 Connection: close를 보낼 때까지) 같은 연결에서
+// 여러 요청을 순차적으로(파이프라이닝 포함) 처리합니다. 핸들러가 패닉을 일으켜도
+// 연결 하나만 500으로 끝나고 프로세스는 계속 동작합니다.
+func (s *Server) handleConnection(conn net.Conn) {
+	defer conn.Close()
+	defer s.recoverPanic(conn)
+
 	reader := bufio.NewReader(conn)
 
+	first := true
+	for {
+		if first {
+			// 첫 요청은 곧바로 헤더 읽기 제한 시간을 적용합니다
+			conn.SetReadDeadline(time.Now().Add(s.HeaderTimeout))
+			first = false
+		} else {
+			// 다음 요청의 첫 바이트가 도착할 때까지는 유휴 제한 시간을 적용하고,
+			// 실제로 바이트가 도착한 뒤에야 (더 짧은) 헤더 읽기 제한 시간으로 좁힙니다.
+			// 그렇지 않으면 루프 맨 위에서 바로 헤더 읽기 제한 시간으로 덮어써져
+			// keep-alive 연결도 매번 짧은 시간 안에 요청을 보내야 하게 됩니다.
+			conn.SetReadDeadline(time.Now().Add(s.IdleTimeout))
+			if _, err := reader.Peek(1); err != nil {
+				if err != io.EOF {
+					log.Printf("❌ 요청 파싱 실패: %v", err)
+				}
+				return
+			}
+			conn.SetReadDeadline(time.Now().Add(s.HeaderTimeout))
+		}
+
+		// HTTP 요청 읽기
+		request, err := s.parseRequest(reader)
+		if err != nil {
+			if err != io.EOF {
+				log.Printf("❌ 요청 파싱 실패: %v", err)
+			}
+			return
+		}
+
+		// 요청 정보 출력
+		fmt.Printf("📥 [%s] %s %s\n", time.Now().Format("15:04:05"), request.Method, request.Path)
+
+		// 라우팅: 경로와 메서드에 맞는 핸들러 찾기
+		var response *HTTPResponse
+		handler, params, allowed, pathFound := s.match(request.Method, request.Path)
+		switch {
+		case handler != nil:
+			request.Params = params
+			response = handler(request)
+		case request.Method == "OPTIONS" && pathFound:
+			// 명시적으로 등록된 OPTIONS 핸들러가 없으면 허용 메서드를 안내합니다
+			response = &HTTPResponse{
+				StatusCode: 204,
+				StatusText: "No Content",
+				Headers:    map[string]string{"Allow": strings.Join(allowed, ", ")},
+			}
+		case pathFound:
+			// 경로는 있지만 이 메서드용 핸들러가 없음
+			response = &HTTPResponse{
+				StatusCode: 405,
+				StatusText: "Method Not Allowed",
+				Headers:    map[string]string{"Allow": strings.Join(allowed, ", ")},
+				Body:       strings.NewReader("<h1>405 - 허용되지 않는 메서드입니다</h1>"),
+			}
+		default:
+			response = &HTTPResponse{
+				StatusCode: 404,
+				StatusText: "Not Found",
+				Body:       strings.NewReader("<h1>404 - 페이지를 찾을 수 없습니다</h1>"),
+			}
+		}
+
+		// 핸들러가 본문을 끝까지 읽지 않았을 수 있으므로, 다음 요청을 위해 남은 바이트를 비웁니다
+		io.Copy(io.Discard, request.Body)
+
+		// 응답 헤더가 없으면 기본값 설정
+		if response.Headers == nil {
+			response.Headers = make(map[string]string)
+		}
+		response.Headers["Content-Type"] = "text/html; charset=utf-8"
+		if !strings.EqualFold(response.Headers["Transfer-Encoding"], "chunked") {
+			// 청크가 아니면 Content-Length가 필요하므로 본문을 미리 다 읽어 길이를 잽니다.
+			// (청크 응답은 핸들러가 준 스트림을 그대로 전달해, 끝을 모르는 본문도
+			// 메모리에 전부 올리지 않고 보낼 수 있습니다 - writeChunked 참고)
+			var buf []byte
+			if response.Body != nil {
+				var readErr error
+				buf, readErr = io.ReadAll(response.Body)
+				if readErr != nil {
+					log.Printf("❌ 응답 본문 읽기 실패: %v", readErr)
+				}
+			}
+			response.Body = bytes.NewReader(buf)
+			response.Headers["Content-Length"] = fmt.Sprintf("%d", len(buf))
+		}
+		response.Headers["Server"] = "MySimpleHTTPServer/1.0"
+
+		keepAlive := shouldKeepAlive(request)
+		if keepAlive {
+			response.Headers["Connection"] = "keep-alive"
+		} else {
+			response.Headers["Connection"] = "close"
+		}
+
+		// HTTP 응답 전송
+		s.sendResponse(conn, response)
+
+		if !keepAlive {
+			return
+		}
+	}
+}
+
+// recoverPanic은 핸들러에서 발생한 패닉을 잡아 스택을 로그로 남기고
+// 연결이 닫히기 전에 500 응답을 보냅니다
+func (s *Server) recoverPanic(conn net.Conn) {
+	if r := recover(); r != nil {
+		log.Printf("💥 핸들러 패닉: %v\n%s", r, debug.Stack())
+		body := "<h1>500 - 서버 내부 오류</h1>"
+		s.sendResponse(conn, &HTTPResponse{
+			StatusCode: 500,
+			StatusText: "Internal Server Error",
+			Headers: map[string]string{
+				"Connection":     "close",
+				"Content-Type":   "text/html; charset=utf-8",
+				"Content-Length": fmt.Sprintf("%d", len(body)),
+			},
+			Body: strings.NewReader(body),
+		})
+	}
+}
+
+// shouldKeepAlive는 Connection 헤더와 HTTP 버전을 기준으로 연결 유지 여부를 결정합니다
+func shouldKeepAlive(request *HTTPRequest) bool {
+	switch strings.ToLower(request.Headers["Connection"]) {
+	case "close":
+		return false
+	case "keep-alive":
+		return true
+	}
+	// HTTP/1.1은 기본값이 keep-alive, HTTP/1.0은 기본값이 close
+	return request.Version == "HTTP/1.1"
+}
+
+// parseRequest는 버퍼에서 HTTP 요청 한 개를 읽어서 파싱합니다. keep-alive 연결에서
+// 재사용할 수 있도록 bufio.Reader를 직접 받습니다.
+func (s *Server) parseRequest(reader *bufio.Reader) (*HTTPRequest, error) {
 	// 첫 번째 줄 읽기: "GET /hello HTTP/1.1"
 	requestLine, err := reader.ReadString('\n')
 	if err != nil {
@@ -158,15 +631,115 @@ func (s *Server) parseRequest(conn net.Conn) (*HTTPRequest, error) {
 		}
 	}
 
+	// 본문 읽기: Transfer-Encoding: chunked가 Content-Length보다 우선합니다
+	switch {
+	case strings.EqualFold(request.Headers["Transfer-Encoding"], "chunked"):
+		request.Body = newChunkedReader(reader)
+	case request.Headers["Content-Length"] != "":
+		length, err := strconv.ParseInt(request.Headers["Content-Length"], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("잘못된 Content-Length: %q", request.Headers["Content-Length"])
+		}
+		request.Body = io.LimitReader(reader, length)
+	default:
+		request.Body = io.LimitReader(reader, 0)
+	}
+
 	return request, nil
 }
 
+// chunkedReader는 Transfer-Encoding: chunked 요청 본문을 디코딩하는 io.Reader입니다
+type chunkedReader struct {
+	r   *bufio.Reader
+	n   int64 // 현재 청크에서 남은 바이트 수
+	err error
+}
+
+func newChunkedReader(r *bufio.Reader) *chunkedReader {
+	return &chunkedReader{r: r}
+}
+
+func (c *chunkedReader) Read(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+
+	if c.n == 0 {
+		size, err := c.readChunkSize()
+		if err != nil {
+			c.err = err
+			return 0, err
+		}
+		if size == 0 {
+			if err := c.skipTrailer(); err != nil {
+				c.err = err
+				return 0, err
+			}
+			c.err = io.EOF
+			return 0, io.EOF
+		}
+		c.n = size
+	}
+
+	if int64(len(p)) > c.n {
+		p = p[:c.n]
+	}
+
+	n, err := c.r.Read(p)
+	c.n -= int64(n)
+	if err != nil {
+		c.err = err
+		return n, err
+	}
+
+	if c.n == 0 {
+		// 청크 데이터 뒤에 오는 CRLF를 소비합니다
+		if _, err := c.r.Discard(2); err != nil {
+			c.err = err
+		}
+	}
+
+	return n, nil
+}
+
+// readChunkSize는 "<크기 16진수>[;확장...]\r\n" 형식의 청크 크기 줄을 읽습니다
+func (c *chunkedReader) readChunkSize() (int64, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return 0, err
+	}
+	line = strings.TrimSpace(line)
+	if i := strings.IndexByte(line, ';'); i >= 0 {
+		line = line[:i] // 청크 확장은 무시합니다
+	}
+	size, err := strconv.ParseInt(line, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("잘못된 청크 크기: %q", line)
+	}
+	return size, nil
+}
+
+// skipTrailer는 마지막(크기 0) 청크 뒤에 오는 트레일러 헤더를 모두 읽어 버립니다
+func (c *chunkedReader) skipTrailer() error {
+	for {
+		line, err := c.r.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(line) == "" {
+			return nil
+		}
+	}
+}
+
 // sendResponse는 HTTP 응답을 클라이언트에게 전송합니다
 func (s *Server) sendResponse(conn net.Conn, response *HTTPResponse) {
 	// 상태 라인 작성
 	statusLine := fmt.Sprintf("HTTP/1.1 %d %s\r\n", response.StatusCode, response.StatusText)
 	conn.Write([]byte(statusLine))
 
+	chunked := strings.EqualFold(response.Headers["Transfer-Encoding"], "chunked")
+
 	// 헤더 작성
 	for key, value := range response.Headers {
 		headerLine := fmt.Sprintf("%s: %s\r\n", key, value)
@@ -176,18 +749,62 @@ func (s *Server) sendResponse(conn net.Conn, response *HTTPResponse) {
 	// 빈 줄 (헤더와 본문 구분)
 	conn.Write([]byte("\r\n"))
 
-	// 본문 작성
-	conn.Write([]byte(response.Body))
+	// 본문 작성: 길이를 미리 알 수 없는 응답은 청크로 나누어 전송합니다
+	if response.Body != nil {
+		if chunked {
+			writeChunked(conn, response.Body)
+		} else {
+			io.Copy(conn, response.Body)
+		}
+	}
 
 	fmt.Printf("📤 [%s] 응답 전송: %d %s\n", time.Now().Format("15:04:05"), response.StatusCode, response.StatusText)
 }
 
+// writeChunked는 본문을 "<크기>\r\n<데이터>\r\n" 청크들로 나누어 전송하고
+// 마지막 크기 0 청크로 마무리합니다
+func writeChunked(conn net.Conn, body io.Reader) error {
+	buf := make([]byte, 4096)
+	for {
+		n, err := body.Read(buf)
+		if n > 0 {
+			if _, werr := fmt.Fprintf(conn, "%x\r\n", n); werr != nil {
+				return werr
+			}
+			if _, werr := conn.Write(buf[:n]); werr != nil {
+				return werr
+			}
+			if _, werr := conn.Write([]byte("\r\n")); werr != nil {
+				return werr
+			}
+		}
+		if err == io.EOF {
+			_, werr := conn.Write([]byte("0\r\n\r\n"))
+			return werr
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Logger는 요청을 처리하기 전후로 걸린 시간을 로그로 남기는 미들웨어입니다
+func Logger(next Handler) Handler {
+	return func(req *HTTPRequest) *HTTPResponse {
+		start := time.Now()
+		response := next(req)
+		log.Printf("%s %s -> %d (%s)", req.Method, req.Path, response.StatusCode, time.Since(start))
+		return response
+	}
+}
+
 func main() {
 	// 서버 생성
 	server := NewServer("localhost:8080")
+	server.Use(Logger)
 
 	// 라우트 등록
-	server.HandleFunc("/", func(req *HTTPRequest) *HTTPResponse {
+	server.GET("/", func(req *HTTPRequest) *HTTPResponse {
 		html := `
 <!DOCTYPE html>
 <html>
@@ -217,29 +834,29 @@ func main() {
 		return &HTTPResponse{
 			StatusCode: 200,
 			StatusText: "OK",
-			Body:       html,
+			Body:       strings.NewReader(html),
 		}
 	})
 
-	server.HandleFunc("/hello", func(req *HTTPRequest) *HTTPResponse {
+	server.GET("/hello", func(req *HTTPRequest) *HTTPResponse {
 		return &HTTPResponse{
 			StatusCode: 200,
 			StatusText: "OK",
-			Body:       "<h1>안녕하세요! 👋</h1><p><a href='/'>홈으로 돌아가기</a></p>",
+			Body:       strings.NewReader("<h1>안녕하세요! 👋</h1><p><a href='/'>홈으로 돌아가기</a></p>"),
 		}
 	})
 
-	server.HandleFunc("/time", func(req *HTTPRequest) *HTTPResponse {
+	server.GET("/time", func(req *HTTPRequest) *HTTPResponse {
 		currentTime := time.Now().Format("2006-01-02 15:04:05")
 		html := fmt.Sprintf("<h1>⏰ 현재 시간</h1><p>%s</p><p><a href='/'>홈으로 돌아가기</a></p>", currentTime)
 		return &HTTPResponse{
 			StatusCode: 200,
 			StatusText: "OK",
-			Body:       html,
+			Body:       strings.NewReader(html),
 		}
 	})
 
-	server.HandleFunc("/about", func(req *HTTPRequest) *HTTPResponse {
+	server.GET("/about", func(req *HTTPRequest) *HTTPResponse {
 		html := `
 <h1>📚 이 서버에 대해</h1>
 <p>이 서버는 Go의 net 패키지만을 사용하여 직접 구현했습니다.</p>
@@ -253,7 +870,16 @@ func main() {
 		return &HTTPResponse{
 			StatusCode: 200,
 			StatusText: "OK",
-			Body:       html,
+			Body:       strings.NewReader(html),
+		}
+	})
+
+	server.GET("/users/:id", func(req *HTTPRequest) *HTTPResponse {
+		html := fmt.Sprintf("<h1>👤 사용자 %s</h1><p><a href='/'>홈으로 돌아가기</a></p>", req.Params["id"])
+		return &HTTPResponse{
+			StatusCode: 200,
+			StatusText: "OK",
+			Body:       strings.NewReader(html),
 		}
 	})
 
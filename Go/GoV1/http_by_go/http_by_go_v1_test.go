@@ -0,0 +1,277 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// net.Pipe로 실제 TCP 없이 클라이언트/서버 양쪽을 흉내 내어 파이프라이닝과
+// keep-alive 동작을 검증합니다.
+func TestHandleConnectionPipelinedKeepAlive(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+
+	s := NewServer("")
+	s.GET("/ping", func(req *HTTPRequest) *HTTPResponse {
+		return &HTTPResponse{StatusCode: 200, StatusText: "OK", Body: strings.NewReader("pong")}
+	})
+
+	go s.handleConnection(server)
+
+	// 두 요청을 파이프라이닝으로 한 번에 보냅니다 (응답을 기다리지 않고 연달아 전송)
+	request := "GET /ping HTTP/1.1\r\nHost: test\r\nConnection: keep-alive\r\n\r\n"
+	if _, err := client.Write([]byte(request + request)); err != nil {
+		t.Fatalf("요청 전송 실패: %v", err)
+	}
+
+	reader := bufio.NewReader(client)
+	for i := 0; i < 2; i++ {
+		status := readStatusLine(t, reader)
+		if !strings.Contains(status, "200") {
+			t.Fatalf("응답 %d: 200을 기대했지만 %q", i, status)
+		}
+		drainHeadersAndBody(t, reader)
+	}
+
+	// Connection: close를 보내면 응답 후 연결이 닫혀야 합니다
+	closeRequest := "GET /ping HTTP/1.1\r\nHost: test\r\nConnection: close\r\n\r\n"
+	if _, err := client.Write([]byte(closeRequest)); err != nil {
+		t.Fatalf("종료 요청 전송 실패: %v", err)
+	}
+	status := readStatusLine(t, reader)
+	if !strings.Contains(status, "200") {
+		t.Fatalf("마지막 응답: 200을 기대했지만 %q", status)
+	}
+	headers := drainHeadersAndBody(t, reader)
+	if !strings.Contains(headers, "Connection: close") {
+		t.Fatalf("Connection: close 헤더를 기대했지만 %q", headers)
+	}
+
+	if _, err := reader.ReadByte(); err == nil {
+		t.Fatalf("연결이 닫혔어야 하는데 더 읽을 데이터가 있습니다")
+	}
+}
+
+// keep-alive 연결에서 HeaderTimeout보다는 길지만 IdleTimeout보다는 짧게 쉬었다가
+// 보낸 다음 요청도 타임아웃 없이 처리되어야 합니다.
+func TestHandleConnectionIdleTimeoutAllowsPauseBetweenRequests(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+
+	s := NewServer("")
+	s.HeaderTimeout = 20 * time.Millisecond
+	s.IdleTimeout = 300 * time.Millisecond
+	s.GET("/ping", func(req *HTTPRequest) *HTTPResponse {
+		return &HTTPResponse{StatusCode: 200, StatusText: "OK", Body: strings.NewReader("pong")}
+	})
+
+	go s.handleConnection(server)
+
+	request := "GET /ping HTTP/1.1\r\nHost: test\r\nConnection: keep-alive\r\n\r\n"
+	if _, err := client.Write([]byte(request)); err != nil {
+		t.Fatalf("첫 요청 전송 실패: %v", err)
+	}
+
+	reader := bufio.NewReader(client)
+	status := readStatusLine(t, reader)
+	if !strings.Contains(status, "200") {
+		t.Fatalf("첫 응답: 200을 기대했지만 %q", status)
+	}
+	drainHeadersAndBody(t, reader)
+
+	// HeaderTimeout(20ms)보다는 길지만 IdleTimeout(300ms)보다는 짧게 쉽니다
+	time.Sleep(100 * time.Millisecond)
+
+	if _, err := client.Write([]byte(request)); err != nil {
+		t.Fatalf("두 번째 요청 전송 실패: %v", err)
+	}
+	status = readStatusLine(t, reader)
+	if !strings.Contains(status, "200") {
+		t.Fatalf("두 번째 응답: 유휴 제한 시간 안에 200을 기대했지만 %q", status)
+	}
+}
+
+func readStatusLine(t *testing.T, reader *bufio.Reader) string {
+	t.Helper()
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		t.Fatalf("상태 줄 읽기 실패: %v", err)
+	}
+	return line
+}
+
+// drainHeadersAndBody는 헤더를 모두 읽고 Content-Length만큼 본문을 읽은 뒤,
+// 검증에 쓸 수 있도록 헤더 원문을 돌려줍니다.
+func drainHeadersAndBody(t *testing.T, reader *bufio.Reader) string {
+	t.Helper()
+
+	var headers strings.Builder
+	contentLength := 0
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("헤더 읽기 실패: %v", err)
+		}
+		headers.WriteString(line)
+
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			break
+		}
+		if strings.HasPrefix(strings.ToLower(trimmed), "content-length:") {
+			fmt.Sscanf(trimmed, "Content-Length: %d", &contentLength)
+		}
+	}
+
+	if contentLength > 0 {
+		body := make([]byte, contentLength)
+		if _, err := io.ReadFull(reader, body); err != nil {
+			t.Fatalf("본문 읽기 실패: %v", err)
+		}
+	}
+
+	return headers.String()
+}
+
+// 같은 경로라도 정적 구간이 ":param"보다 먼저 매칭되어야 합니다
+func TestRouteStaticBeatsParam(t *testing.T) {
+	s := NewServer("")
+	s.GET("/users/me", func(req *HTTPRequest) *HTTPResponse {
+		return &HTTPResponse{StatusCode: 200, StatusText: "OK", Body: strings.NewReader("static")}
+	})
+	s.GET("/users/:id", func(req *HTTPRequest) *HTTPResponse {
+		return &HTTPResponse{StatusCode: 200, StatusText: "OK", Body: strings.NewReader("param:" + req.Params["id"])}
+	})
+
+	if body := matchAndRun(t, s, "GET", "/users/me"); body != "static" {
+		t.Fatalf("정적 경로가 우선해야 하는데 %q", body)
+	}
+	if body := matchAndRun(t, s, "GET", "/users/42"); body != "param:42" {
+		t.Fatalf("파라미터 경로가 42를 캡처해야 하는데 %q", body)
+	}
+}
+
+// "*path" 와일드카드는 남은 경로 구간을 전부 하나의 파라미터로 캡처해야 합니다
+func TestRouteWildcardCapturesRemainingPath(t *testing.T) {
+	s := NewServer("")
+	s.GET("/files/*path", func(req *HTTPRequest) *HTTPResponse {
+		return &HTTPResponse{StatusCode: 200, StatusText: "OK", Body: strings.NewReader(req.Params["path"])}
+	})
+
+	if body := matchAndRun(t, s, "GET", "/files/a/b/c.txt"); body != "a/b/c.txt" {
+		t.Fatalf("와일드카드가 a/b/c.txt를 캡처해야 하는데 %q", body)
+	}
+}
+
+// 등록되지 않은 경로는 404로 이어져야 합니다 (pathFound=false)
+func TestMatchNotFoundForUnregisteredPath(t *testing.T) {
+	s := NewServer("")
+	s.GET("/x", func(req *HTTPRequest) *HTTPResponse {
+		return &HTTPResponse{StatusCode: 200, StatusText: "OK"}
+	})
+
+	if _, _, _, pathFound := s.match("GET", "/nope"); pathFound {
+		t.Fatalf("등록되지 않은 경로인데 pathFound=true")
+	}
+}
+
+// 경로는 있지만 메서드가 등록되어 있지 않으면 405용 Allow 목록을 돌려줘야 하고,
+// OPTIONS 핸들러가 이미 등록되어 있으면 Allow 목록에 OPTIONS가 중복되면 안 됩니다
+func TestMatchAllowedMethodsNoDuplicateOptions(t *testing.T) {
+	s := NewServer("")
+	s.GET("/x", func(req *HTTPRequest) *HTTPResponse {
+		return &HTTPResponse{StatusCode: 200, StatusText: "OK"}
+	})
+	s.OPTIONS("/x", func(req *HTTPRequest) *HTTPResponse {
+		return &HTTPResponse{StatusCode: 204, StatusText: "No Content"}
+	})
+
+	_, _, allowed, pathFound := s.match("DELETE", "/x")
+	if !pathFound {
+		t.Fatalf("경로는 존재하는데 pathFound=false")
+	}
+	if got := strings.Join(allowed, ", "); got != "GET, OPTIONS" {
+		t.Fatalf("Allow 목록이 GET, OPTIONS 한 번씩이어야 하는데 %q", got)
+	}
+
+	// OPTIONS를 명시적으로 등록하지 않은 경로는 자동으로 Allow에 OPTIONS가 추가되어야 합니다
+	s.POST("/y", func(req *HTTPRequest) *HTTPResponse {
+		return &HTTPResponse{StatusCode: 200, StatusText: "OK"}
+	})
+	_, _, allowed, pathFound = s.match("DELETE", "/y")
+	if !pathFound {
+		t.Fatalf("경로는 존재하는데 pathFound=false")
+	}
+	if got := strings.Join(allowed, ", "); got != "OPTIONS, POST" {
+		t.Fatalf("Allow 목록이 OPTIONS, POST 한 번씩이어야 하는데 %q", got)
+	}
+}
+
+// matchAndRun은 s.match로 찾은 핸들러를 실행해 본문 문자열을 돌려주는 테스트 보조 함수입니다
+func matchAndRun(t *testing.T, s *Server, method, path string) string {
+	t.Helper()
+	handler, params, _, pathFound := s.match(method, path)
+	if !pathFound || handler == nil {
+		t.Fatalf("%s %s 에 매칭되는 핸들러를 찾지 못했습니다", method, path)
+	}
+	response := handler(&HTTPRequest{Method: method, Path: path, Params: params})
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		t.Fatalf("응답 본문 읽기 실패: %v", err)
+	}
+	return string(body)
+}
+
+// handleConnection을 통해 실제 HTTP 응답으로 404/405/OPTIONS 분기를 검증합니다
+func TestHandleConnectionRouterDispatch(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+
+	s := NewServer("")
+	s.GET("/x", func(req *HTTPRequest) *HTTPResponse {
+		return &HTTPResponse{StatusCode: 200, StatusText: "OK", Body: strings.NewReader("ok")}
+	})
+	s.OPTIONS("/x", func(req *HTTPRequest) *HTTPResponse {
+		return &HTTPResponse{StatusCode: 204, StatusText: "No Content", Headers: map[string]string{"X-Handler": "options"}}
+	})
+
+	go s.handleConnection(server)
+	reader := bufio.NewReader(client)
+
+	// 등록되지 않은 경로 -> 404
+	if _, err := client.Write([]byte("GET /missing HTTP/1.1\r\nHost: test\r\nConnection: keep-alive\r\n\r\n")); err != nil {
+		t.Fatalf("요청 전송 실패: %v", err)
+	}
+	if status := readStatusLine(t, reader); !strings.Contains(status, "404") {
+		t.Fatalf("404를 기대했지만 %q", status)
+	}
+	drainHeadersAndBody(t, reader)
+
+	// 경로는 있지만 메서드가 등록되어 있지 않음 -> 405, Allow 헤더에 OPTIONS가 한 번만
+	if _, err := client.Write([]byte("DELETE /x HTTP/1.1\r\nHost: test\r\nConnection: keep-alive\r\n\r\n")); err != nil {
+		t.Fatalf("요청 전송 실패: %v", err)
+	}
+	if status := readStatusLine(t, reader); !strings.Contains(status, "405") {
+		t.Fatalf("405를 기대했지만 %q", status)
+	}
+	headers := drainHeadersAndBody(t, reader)
+	if strings.Count(headers, "OPTIONS") != 1 {
+		t.Fatalf("Allow 헤더에 OPTIONS가 정확히 한 번 있어야 하는데 %q", headers)
+	}
+
+	// 등록된 OPTIONS 핸들러가 그대로 호출되어야 합니다 (자동 204 대신)
+	if _, err := client.Write([]byte("OPTIONS /x HTTP/1.1\r\nHost: test\r\nConnection: close\r\n\r\n")); err != nil {
+		t.Fatalf("요청 전송 실패: %v", err)
+	}
+	if status := readStatusLine(t, reader); !strings.Contains(status, "204") {
+		t.Fatalf("204를 기대했지만 %q", status)
+	}
+	if headers := drainHeadersAndBody(t, reader); !strings.Contains(headers, "X-Handler: options") {
+		t.Fatalf("등록된 OPTIONS 핸들러의 X-Handler 헤더를 기대했지만 %q", headers)
+	}
+}
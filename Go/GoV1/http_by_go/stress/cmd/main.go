@@ -0,0 +1,52 @@
+// tif stress는 http_by_go 서버에 부하를 가해 QPS와 지연시간을 측정하는
+// 독립 실행형 커맨드입니다.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"yesaroun/TIF/Go/GoV1/http_by_go/stress"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:8080", "대상 서버 주소 (host:port)")
+	method := flag.String("method", "GET", "HTTP 메서드")
+	path := flag.String("path", "/", "요청 경로")
+	file := flag.String("file", "", "curl 스타일 요청 파일 경로 (지정하면 -method/-path 대신 사용)")
+	concurrency := flag.Int("c", 10, "동시 연결(워커) 수")
+	requests := flag.Int("n", 100, "워커당 보낼 요청 수")
+	flag.Parse()
+
+	cfg := stress.Config{
+		Addr:        *addr,
+		Method:      *method,
+		Path:        *path,
+		Concurrency: *concurrency,
+		Requests:    *requests,
+	}
+
+	if *file != "" {
+		parsed, err := stress.ParseRequestFile(*file)
+		if err != nil {
+			log.Fatalf("요청 파일 읽기 실패: %v", err)
+		}
+		cfg.Method = parsed.Method
+		cfg.Path = parsed.Path
+		cfg.Headers = parsed.Headers
+		cfg.Body = parsed.Body
+	}
+
+	fmt.Printf("🔥 %s 에 연결 %d개 x 연결당 %d개 요청을 보냅니다\n", cfg.Addr, cfg.Concurrency, cfg.Requests)
+
+	summary, err := stress.Run(cfg)
+	if err != nil {
+		log.Fatalf("부하 테스트 실패: %v", err)
+	}
+
+	fmt.Println("\n📈 최종 결과:")
+	if err := summary.PrintJSON(); err != nil {
+		log.Fatalf("결과 출력 실패: %v", err)
+	}
+}
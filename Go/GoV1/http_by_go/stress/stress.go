@@ -0,0 +1,388 @@
+// Package stress는 http_by_go 서버를 대상으로 부하를 생성하는 간단한 클라이언트입니다.
+// 여러 개의 지속(keep-alive) 연결을 열어 연결마다 요청을 순차적으로 보내고, QPS와 지연시간 분위수를 집계합니다.
+// 한 연결에서는 응답을 받은 뒤에 다음 요청을 보내며, 파이프라이닝은 하지 않습니다.
+package stress
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Config는 부하 테스트 실행 설정입니다
+type Config struct {
+	Addr        string            // 대상 서버 주소 (host:port)
+	Method      string            // HTTP 메서드 (기본 GET)
+	Path        string            // 요청 경로
+	Headers     map[string]string // 추가 요청 헤더
+	Body        string            // 요청 본문
+	Concurrency int               // 동시 연결(워커) 수
+	Requests    int               // 워커당 보낼 요청 수
+}
+
+// result는 요청 한 건의 결과입니다
+type result struct {
+	statusCode int
+	latency    time.Duration
+	bytes      int64
+	err        error
+}
+
+// Summary는 부하 테스트가 끝난 뒤의 집계 결과입니다
+type Summary struct {
+	TotalRequests int           `json:"total_requests"`
+	Errors        int           `json:"errors"`
+	Duration      time.Duration `json:"duration_ns"`
+	QPS           float64       `json:"qps"`
+	P50           time.Duration `json:"p50_ns"`
+	P90           time.Duration `json:"p90_ns"`
+	P99           time.Duration `json:"p99_ns"`
+	TotalBytes    int64         `json:"total_bytes"`
+	StatusCounts  map[int]int   `json:"status_counts"`
+}
+
+// Run은 설정에 따라 동시에 여러 연결을 열어 각 연결에서 요청을 순차적으로 보내고 결과를 집계합니다
+func Run(cfg Config) (*Summary, error) {
+	if cfg.Concurrency <= 0 {
+		cfg.Concurrency = 1
+	}
+	if cfg.Requests <= 0 {
+		cfg.Requests = 1
+	}
+	if cfg.Method == "" {
+		cfg.Method = "GET"
+	}
+	if cfg.Path == "" {
+		cfg.Path = "/"
+	}
+
+	results := make(chan result, cfg.Concurrency*cfg.Requests)
+	var wg sync.WaitGroup
+	var done int64
+
+	start := time.Now()
+	for i := 0; i < cfg.Concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			worker(cfg, results, &done)
+		}()
+	}
+
+	// 1초마다 진행 상황 한 줄(완료 수, QPS)을 덮어써서 갱신합니다 (실시간 업데이트)
+	stopProgress := make(chan struct{})
+	go printProgress(&done, cfg.Concurrency*cfg.Requests, start, stopProgress)
+
+	wg.Wait()
+	close(results)
+	close(stopProgress)
+
+	return summarize(results, time.Since(start)), nil
+}
+
+// worker는 연결 하나를 열어 요청을 반복해서 보냅니다. 각 요청의 응답을 다 읽은 뒤에
+// 다음 요청을 보내므로, 응답을 기다리지 않고 연달아 보내는 파이프라이닝은 하지 않습니다
+func worker(cfg Config, results chan<- result, done *int64) {
+	conn, err := net.DialTimeout("tcp", cfg.Addr, 5*time.Second)
+	if err != nil {
+		for i := 0; i < cfg.Requests; i++ {
+			results <- result{err: err}
+			atomic.AddInt64(done, 1)
+		}
+		return
+	}
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	request := buildRequest(cfg)
+
+	for i := 0; i < cfg.Requests; i++ {
+		reqStart := time.Now()
+		if _, err := conn.Write(request); err != nil {
+			results <- result{err: err}
+			atomic.AddInt64(done, 1)
+			return
+		}
+
+		statusCode, n, err := readResponse(reader)
+		results <- result{statusCode: statusCode, latency: time.Since(reqStart), bytes: n, err: err}
+		atomic.AddInt64(done, 1)
+		if err != nil {
+			return // 연결이 끊어졌으므로 이 워커는 종료
+		}
+	}
+}
+
+// buildRequest는 설정으로부터 원본 HTTP/1.1 요청 바이트를 만듭니다
+func buildRequest(cfg Config) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "%s %s HTTP/1.1\r\n", cfg.Method, cfg.Path)
+	fmt.Fprintf(&b, "Host: %s\r\n", cfg.Addr)
+	b.WriteString("Connection: keep-alive\r\n")
+	if cfg.Body != "" {
+		fmt.Fprintf(&b, "Content-Length: %d\r\n", len(cfg.Body))
+	}
+	for key, value := range cfg.Headers {
+		fmt.Fprintf(&b, "%s: %s\r\n", key, value)
+	}
+	b.WriteString("\r\n")
+	b.WriteString(cfg.Body)
+	return b.Bytes()
+}
+
+// readResponse는 상태 줄과 헤더를 읽고, Content-Length 또는 chunked 본문을 끝까지 소비합니다
+func readResponse(reader *bufio.Reader) (statusCode int, totalBytes int64, err error) {
+	statusLine, err := reader.ReadString('\n')
+	if err != nil {
+		return 0, 0, err
+	}
+	parts := strings.SplitN(strings.TrimSpace(statusLine), " ", 3)
+	if len(parts) < 2 {
+		return 0, 0, fmt.Errorf("잘못된 상태 줄: %s", statusLine)
+	}
+	statusCode, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	totalBytes = int64(len(statusLine))
+
+	headers := make(map[string]string)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return statusCode, totalBytes, err
+		}
+		totalBytes += int64(len(line))
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		if i := strings.Index(line, ":"); i > 0 {
+			headers[strings.TrimSpace(line[:i])] = strings.TrimSpace(line[i+1:])
+		}
+	}
+
+	var body io.Reader
+	switch {
+	case strings.EqualFold(headers["Transfer-Encoding"], "chunked"):
+		body = newChunkedReader(reader)
+	case headers["Content-Length"] != "":
+		length, convErr := strconv.ParseInt(headers["Content-Length"], 10, 64)
+		if convErr != nil {
+			return statusCode, totalBytes, convErr
+		}
+		body = io.LimitReader(reader, length)
+	default:
+		body = io.LimitReader(reader, 0)
+	}
+
+	n, err := io.Copy(io.Discard, body)
+	totalBytes += n
+	return statusCode, totalBytes, err
+}
+
+// printProgress는 1초 간격으로 지금까지 완료된 요청 수와 QPS를 출력합니다
+func printProgress(done *int64, total int, start time.Time, stop <-chan struct{}) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			completed := atomic.LoadInt64(done)
+			qps := float64(completed) / time.Since(start).Seconds()
+			fmt.Printf("\r📊 %d/%d 완료 | %.0f req/s", completed, total, qps)
+		case <-stop:
+			fmt.Println()
+			return
+		}
+	}
+}
+
+// summarize는 채널로 모인 개별 결과를 집계해 Summary를 만듭니다
+func summarize(results <-chan result, elapsed time.Duration) *Summary {
+	var latencies []time.Duration
+	statusCounts := make(map[int]int)
+	var totalBytes int64
+	var errs, total int
+
+	for r := range results {
+		total++
+		if r.err != nil {
+			errs++
+			continue
+		}
+		latencies = append(latencies, r.latency)
+		statusCounts[r.statusCode]++
+		totalBytes += r.bytes
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	return &Summary{
+		TotalRequests: total,
+		Errors:        errs,
+		Duration:      elapsed,
+		QPS:           float64(total) / elapsed.Seconds(),
+		P50:           percentile(latencies, 0.50),
+		P90:           percentile(latencies, 0.90),
+		P99:           percentile(latencies, 0.99),
+		TotalBytes:    totalBytes,
+		StatusCounts:  statusCounts,
+	}
+}
+
+// percentile은 정렬된 지연시간 목록에서 p(0~1) 분위수를 근사로 구합니다
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	index := int(p * float64(len(sorted)-1))
+	return sorted[index]
+}
+
+// PrintJSON은 최종 결과를 JSON으로 표준 출력에 씁니다
+func (s *Summary) PrintJSON() error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s)
+}
+
+// chunkedReader는 Transfer-Encoding: chunked 응답 본문을 디코딩하는 io.Reader입니다
+// (http_by_go 서버의 구현과 동일한 방식이지만, 클라이언트 쪽에서 응답을 읽기 위한 것입니다)
+type chunkedReader struct {
+	r   *bufio.Reader
+	n   int64
+	err error
+}
+
+func newChunkedReader(r *bufio.Reader) *chunkedReader {
+	return &chunkedReader{r: r}
+}
+
+func (c *chunkedReader) Read(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+
+	if c.n == 0 {
+		size, err := c.readChunkSize()
+		if err != nil {
+			c.err = err
+			return 0, err
+		}
+		if size == 0 {
+			if err := c.skipTrailer(); err != nil {
+				c.err = err
+				return 0, err
+			}
+			c.err = io.EOF
+			return 0, io.EOF
+		}
+		c.n = size
+	}
+
+	if int64(len(p)) > c.n {
+		p = p[:c.n]
+	}
+
+	n, err := c.r.Read(p)
+	c.n -= int64(n)
+	if err != nil {
+		c.err = err
+		return n, err
+	}
+
+	if c.n == 0 {
+		if _, err := c.r.Discard(2); err != nil {
+			c.err = err
+		}
+	}
+
+	return n, nil
+}
+
+func (c *chunkedReader) readChunkSize() (int64, error) {
+	line, err := c.r.ReadString('\n')
+	if err != nil {
+		return 0, err
+	}
+	line = strings.TrimSpace(line)
+	if i := strings.IndexByte(line, ';'); i >= 0 {
+		line = line[:i]
+	}
+	size, err := strconv.ParseInt(line, 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("잘못된 청크 크기: %q", line)
+	}
+	return size, nil
+}
+
+func (c *chunkedReader) skipTrailer() error {
+	for {
+		line, err := c.r.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		if strings.TrimSpace(line) == "" {
+			return nil
+		}
+	}
+}
+
+// ParsedRequest는 curl 스타일 요청 파일에서 읽어온 요청 정보입니다
+type ParsedRequest struct {
+	Method  string
+	Path    string
+	Headers map[string]string
+	Body    string
+}
+
+// ParseRequestFile은 "METHOD /path HTTP/1.1" 요청 줄로 시작하고, 그 뒤에
+// "Header: value" 줄들과 빈 줄, 본문이 이어지는 curl 스타일 요청 파일을 읽습니다
+func ParseRequestFile(path string) (*ParsedRequest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	lines := strings.Split(string(data), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) == "" {
+		return nil, fmt.Errorf("빈 요청 파일: %s", path)
+	}
+
+	requestLine := strings.Fields(strings.TrimSpace(lines[0]))
+	if len(requestLine) < 2 {
+		return nil, fmt.Errorf("잘못된 요청 줄: %s", lines[0])
+	}
+
+	parsed := &ParsedRequest{
+		Method:  requestLine[0],
+		Path:    requestLine[1],
+		Headers: make(map[string]string),
+	}
+
+	i := 1
+	for ; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], "\r")
+		if strings.TrimSpace(line) == "" {
+			i++
+			break
+		}
+		if idx := strings.Index(line, ":"); idx > 0 {
+			parsed.Headers[strings.TrimSpace(line[:idx])] = strings.TrimSpace(line[idx+1:])
+		}
+	}
+
+	parsed.Body = strings.Join(lines[i:], "\n")
+	return parsed, nil
+}